@@ -0,0 +1,191 @@
+package survey
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FilterResult describes how a single option matched a filter query. Index
+// refers back into the original options slice that was filtered, Score
+// ranks the match against the others (higher sorts first), and MatchedRunes
+// holds the index of every rune within the option that the query matched,
+// in ascending order, so a template can highlight them.
+type FilterResult struct {
+	Index        int
+	Score        int
+	MatchedRunes []int
+}
+
+// FilterFunc narrows a list of options down to the ones relevant to filter,
+// ranked so the best matches come first. Select and MultiSelect call the
+// configured FilterFunc (or DefaultFilter, if none is set) on every
+// keystroke.
+type FilterFunc func(filter string, options []string) []FilterResult
+
+// SubstringFilter is a FilterFunc that keeps options containing filter as a
+// case-insensitive substring, in their original order. It's the matching
+// behavior survey used before fuzzy matching was introduced, kept around
+// for callers that would rather have exact substring semantics than
+// fzf-style fuzzy ranking.
+func SubstringFilter(filter string, options []string) []FilterResult {
+	lower := []rune(strings.ToLower(filter))
+
+	results := []FilterResult{}
+	for i, opt := range options {
+		lowerOpt := strings.ToLower(opt)
+		byteIdx := strings.Index(lowerOpt, string(lower))
+		if byteIdx == -1 {
+			continue
+		}
+		// byteIdx is a byte offset into lowerOpt; MatchedRunes is rune-indexed
+		// (highlightOption walks []rune(value)), so convert before using it
+		runeIdx := len([]rune(lowerOpt[:byteIdx]))
+
+		matched := make([]int, len(lower))
+		for j := range matched {
+			matched[j] = runeIdx + j
+		}
+		results = append(results, FilterResult{Index: i, MatchedRunes: matched})
+	}
+	return results
+}
+
+// fuzzy match scoring constants, tuned the way VS Code / fzf tune theirs:
+// a plain match is worth the most, consecutive matches and matches that
+// land on a word boundary (after a separator, or at a camelCase hump) are
+// rewarded, and skipping characters between matches costs a little.
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyScoreConsecutive  = 8
+	fuzzyScoreWordBoundary = 10
+	fuzzyScoreGap          = -4
+)
+
+// DefaultFilter is a FilterFunc implementing fzf/VS Code style fuzzy
+// matching: the runes of filter must appear in option in order, though not
+// necessarily contiguously, and matches are scored with a
+// Smith-Waterman-style alignment so tighter, boundary-aligned matches
+// outrank loose ones. Matching is case-insensitive.
+func DefaultFilter(filter string, options []string) []FilterResult {
+	if filter == "" {
+		results := make([]FilterResult, len(options))
+		for i := range options {
+			results[i] = FilterResult{Index: i}
+		}
+		return results
+	}
+
+	results := []FilterResult{}
+	for i, opt := range options {
+		matched, score, ok := fuzzyMatch(filter, opt)
+		if !ok {
+			continue
+		}
+		results = append(results, FilterResult{Index: i, Score: score, MatchedRunes: matched})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// fuzzyMatch reports whether every rune of filter appears, in order, within
+// option (case-insensitively), returning the indices it matched against and
+// an alignment score. It's computed with the same shape of dynamic program
+// as a Smith-Waterman local alignment: at every (filter rune, option rune)
+// pair we either skip the option rune or consume it as a match, and keep
+// whichever choice scores higher.
+func fuzzyMatch(filter, option string) (matched []int, score int, ok bool) {
+	f := []rune(strings.ToLower(filter))
+	o := []rune(option)
+	lowerO := []rune(strings.ToLower(option))
+	if len(f) == 0 || len(f) > len(o) {
+		return nil, 0, false
+	}
+
+	boundary := wordBoundaries(o)
+
+	const negInf = math.MinInt32 / 2
+
+	dp := make([][]int, len(f)+1)
+	matchedHere := make([][]bool, len(f)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(o)+1)
+		matchedHere[i] = make([]bool, len(o)+1)
+	}
+	for j := 0; j <= len(o); j++ {
+		dp[0][j] = 0
+	}
+	for i := 1; i <= len(f); i++ {
+		dp[i][0] = negInf
+	}
+
+	for i := 1; i <= len(f); i++ {
+		for j := 1; j <= len(o); j++ {
+			skip := dp[i][j-1]
+
+			take := negInf
+			if lowerO[j-1] == f[i-1] && dp[i-1][j-1] != negInf {
+				take = dp[i-1][j-1] + fuzzyScoreMatch
+				if boundary[j-1] {
+					take += fuzzyScoreWordBoundary
+				}
+				if matchedHere[i-1][j-1] {
+					take += fuzzyScoreConsecutive
+				} else if i > 1 {
+					take += fuzzyScoreGap
+				}
+			}
+
+			if take > skip {
+				dp[i][j] = take
+				matchedHere[i][j] = true
+			} else {
+				dp[i][j] = skip
+			}
+		}
+	}
+
+	if dp[len(f)][len(o)] == negInf {
+		return nil, 0, false
+	}
+
+	matched = make([]int, 0, len(f))
+	i, j := len(f), len(o)
+	for i > 0 {
+		if matchedHere[i][j] {
+			matched = append(matched, j-1)
+			i--
+			j--
+		} else {
+			j--
+		}
+	}
+	for l, r := 0, len(matched)-1; l < r; l, r = l+1, r-1 {
+		matched[l], matched[r] = matched[r], matched[l]
+	}
+
+	return matched, dp[len(f)][len(o)], true
+}
+
+// wordBoundaries reports, for every rune in s, whether it starts a new
+// "word": the very first rune, any rune following a non-letter/non-digit
+// separator, or an upper-case rune immediately following a letter or digit
+// that isn't upper-case (a camelCase hump, or a capital after a digit).
+func wordBoundaries(s []rune) []bool {
+	boundary := make([]bool, len(s))
+	for i, r := range s {
+		switch {
+		case i == 0:
+			boundary[i] = true
+		case !unicode.IsLetter(s[i-1]) && !unicode.IsDigit(s[i-1]):
+			boundary[i] = true
+		case unicode.IsUpper(r) && !unicode.IsUpper(s[i-1]):
+			boundary[i] = true
+		}
+	}
+	return boundary
+}
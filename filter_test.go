@@ -0,0 +1,74 @@
+package survey
+
+import "testing"
+
+func TestDefaultFilterRanksTighterMatchesFirst(t *testing.T) {
+	options := []string{"scattering", "string", "something else entirely"}
+
+	results := DefaultFilter("str", options)
+	if len(results) != 3 {
+		t.Fatalf("expected all 3 options to match 'str' as a fuzzy subsequence, got %+v", results)
+	}
+	if options[results[0].Index] != "string" {
+		t.Errorf("expected %q (a contiguous match) to rank above %q, got order %+v",
+			"string", "scattering", results)
+	}
+}
+
+func TestDefaultFilterIsCaseInsensitive(t *testing.T) {
+	results := DefaultFilter("STR", []string{"string"})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+}
+
+func TestDefaultFilterEmptyFilterReturnsEverythingUnranked(t *testing.T) {
+	options := []string{"a", "b", "c"}
+	results := DefaultFilter("", options)
+	if len(results) != len(options) {
+		t.Fatalf("expected every option back, got %+v", results)
+	}
+	for i, r := range results {
+		if r.Index != i || r.Score != 0 {
+			t.Errorf("expected option %d to come back unranked and in order, got %+v", i, r)
+		}
+	}
+}
+
+func TestDefaultFilterRequiresInOrderRunes(t *testing.T) {
+	results := DefaultFilter("bca", []string{"abc"})
+	if len(results) != 0 {
+		t.Errorf("expected no match since 'bca' isn't a subsequence of 'abc', got %+v", results)
+	}
+}
+
+func TestSubstringFilterMatchesRuneOffsetsNotByteOffsets(t *testing.T) {
+	results := SubstringFilter("bar", []string{"café bar"})
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+
+	// "café" is 4 runes but 5 bytes (é is 2 bytes in UTF-8), so a byte offset
+	// into the match would land one rune too far into "bar"
+	want := []int{5, 6, 7}
+	got := results[0].MatchedRunes
+	if len(got) != len(want) {
+		t.Fatalf("MatchedRunes = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("MatchedRunes = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSubstringFilterKeepsOriginalOrder(t *testing.T) {
+	options := []string{"zzz_needle", "needle_aaa", "no match here"}
+	results := SubstringFilter("needle", options)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(results))
+	}
+	if results[0].Index != 0 || results[1].Index != 1 {
+		t.Errorf("expected matches in original order, got %+v", results)
+	}
+}
@@ -0,0 +1,131 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// FormQuestion extends Question with the two things Form adds on top of a
+// plain Ask: the ability to skip a question based on answers already
+// collected, and a default computed from those same answers rather than a
+// fixed zero value.
+type FormQuestion struct {
+	Question
+	// When, if set, is consulted with the answers collected so far before
+	// the question is asked; returning false skips it entirely.
+	When func(answers interface{}) bool
+	// Default, if set, computes this question's default from the answers
+	// collected so far. It's written onto the prompt's own Default field,
+	// so it only has an effect on prompts that have one.
+	Default func(answers interface{}) interface{}
+}
+
+// CrossValidator checks invariants that span more than one question, once
+// a Form has an answer for each of them. It returns an error per offending
+// question name; only those questions get re-asked.
+type CrossValidator func(answers interface{}) map[string]error
+
+// Form drives a sequence of FormQuestions the way Ask drives Questions, but
+// lets later questions depend on earlier answers: questions can be skipped
+// with When, given an answer-derived Default, and revisited in response to
+// a form-wide CrossValidate once every question has been answered once.
+type Form struct {
+	Questions     []*FormQuestion
+	CrossValidate CrossValidator
+}
+
+// Run asks every applicable question in order, writing each answer into
+// response the way Ask does, then repeatedly applies CrossValidate (if
+// any), re-prompting only the questions it names, until it reports no more
+// problems.
+func (f *Form) Run(response interface{}, opts ...AskOpt) error {
+	// if we weren't passed a place to record the answers
+	if response == nil {
+		// we can't go any further
+		return errors.New("cannot call Form.Run() with a nil reference to record the answers")
+	}
+
+	byName := make(map[string]*FormQuestion, len(f.Questions))
+	for _, fq := range f.Questions {
+		byName[fq.Name] = fq
+
+		// skip questions that don't apply given what's been answered so far
+		if fq.When != nil && !fq.When(response) {
+			continue
+		}
+
+		if err := f.ask(fq, response, opts); err != nil {
+			return err
+		}
+	}
+
+	// no cross-question invariants to enforce
+	if f.CrossValidate == nil {
+		return nil
+	}
+
+	// re-run CrossValidate, re-asking only the questions it flags, until
+	// it has nothing left to complain about
+	for {
+		invalid := f.CrossValidate(response)
+		if len(invalid) == 0 {
+			return nil
+		}
+
+		for name := range invalid {
+			fq, ok := byName[name]
+			if !ok {
+				continue
+			}
+			// a question that no longer applies stays skipped, even if
+			// CrossValidate still names it; that's a CrossValidate bug (it
+			// flagged a question whose own When disagrees), and looping on
+			// it would just get the same map back forever, so report it
+			// instead of spinning
+			if fq.When != nil && !fq.When(response) {
+				return fmt.Errorf("CrossValidate flagged %q but it is currently skipped by When", name)
+			}
+			if err := f.ask(fq, response, opts); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ask computes fq's answer-derived default (if any) and asks it as a
+// single-question Ask, so validation, transformation, and stdio plumbing
+// all behave exactly as they do outside a Form.
+func (f *Form) ask(fq *FormQuestion, response interface{}, opts []AskOpt) error {
+	if fq.Default != nil {
+		setPromptDefault(fq.Prompt, fq.Default(response))
+	}
+
+	q := fq.Question
+	return Ask([]*Question{&q}, response, opts...)
+}
+
+// setPromptDefault writes value onto prompt's exported Default field, if it
+// has one of a matching type. Every built-in prompt (Input, Select,
+// MultiSelect, Confirm, ...) exposes its static default this way, so this
+// is how a Form hands it an answer-derived one instead.
+func setPromptDefault(prompt Prompt, value interface{}) {
+	if value == nil {
+		return
+	}
+
+	v := reflect.ValueOf(prompt)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+
+	field := v.Elem().FieldByName("Default")
+	if !field.IsValid() || !field.CanSet() {
+		return
+	}
+
+	val := reflect.ValueOf(value)
+	if val.Type().AssignableTo(field.Type()) {
+		field.Set(val)
+	}
+}
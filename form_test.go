@@ -0,0 +1,104 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// stubPrompt is a minimal Prompt that never expects to be interactively
+// prompted: every test below resolves its questions through an
+// AnswerSource, so Prompt() is only reached if a question wasn't resolved
+// (or wasn't skipped) the way the test expects.
+type stubPrompt struct {
+	Default string
+}
+
+func (p *stubPrompt) Prompt(config *PromptConfig) (interface{}, error) {
+	return nil, errors.New("stubPrompt.Prompt should not have been called")
+}
+func (p *stubPrompt) Cleanup(interface{}) error { return nil }
+func (p *stubPrompt) Error(error) error         { return nil }
+
+// mapAnswerSource resolves a question's answer by name, the way
+// WithAnswerFile's in-memory form would, without touching disk.
+type mapAnswerSource map[string]interface{}
+
+func (m mapAnswerSource) Read(name string) (interface{}, bool, error) {
+	v, ok := m[name]
+	return v, ok, nil
+}
+
+func TestFormSkipsQuestionsWhenWhenIsFalse(t *testing.T) {
+	form := &Form{
+		Questions: []*FormQuestion{
+			{Question: Question{Name: "shouldAsk", Prompt: &stubPrompt{}}},
+			{
+				Question: Question{Name: "shouldSkip", Prompt: &stubPrompt{}},
+				When:     func(answers interface{}) bool { return false },
+			},
+		},
+	}
+
+	response := map[string]interface{}{}
+	if err := form.Run(&response, WithAnswerSource(mapAnswerSource{"shouldAsk": "yes"})); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if response["shouldAsk"] != "yes" {
+		t.Errorf("expected shouldAsk to be answered, got %v", response["shouldAsk"])
+	}
+	if _, ok := response["shouldSkip"]; ok {
+		t.Errorf("expected shouldSkip to be skipped entirely, got an answer")
+	}
+}
+
+func TestFormDefaultDerivedFromPriorAnswers(t *testing.T) {
+	derivedPrompt := &stubPrompt{}
+	form := &Form{
+		Questions: []*FormQuestion{
+			{Question: Question{Name: "base", Prompt: &stubPrompt{}}},
+			{
+				Question: Question{Name: "derived", Prompt: derivedPrompt},
+				Default: func(answers interface{}) interface{} {
+					resp := answers.(*map[string]interface{})
+					return fmt.Sprintf("default-for-%v", (*resp)["base"])
+				},
+			},
+		},
+	}
+
+	response := map[string]interface{}{}
+	source := mapAnswerSource{"base": "x", "derived": "y"}
+	if err := form.Run(&response, WithAnswerSource(source)); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+
+	if derivedPrompt.Default != "default-for-x" {
+		t.Errorf("expected derived's Default to be computed from base's answer, got %q", derivedPrompt.Default)
+	}
+}
+
+func TestFormCrossValidateRespectsWhen(t *testing.T) {
+	form := &Form{
+		Questions: []*FormQuestion{
+			{Question: Question{Name: "a", Prompt: &stubPrompt{}}},
+			{
+				Question: Question{Name: "b", Prompt: &stubPrompt{}},
+				When:     func(answers interface{}) bool { return false },
+			},
+		},
+		CrossValidate: func(answers interface{}) map[string]error {
+			// "b" was skipped by When, but this CrossValidator names it
+			// anyway - a bug in the CrossValidator, not something Run
+			// should loop on forever trying to satisfy
+			return map[string]error{"b": errors.New("flagged")}
+		},
+	}
+
+	response := map[string]interface{}{}
+	err := form.Run(&response, WithAnswerSource(mapAnswerSource{"a": "yes"}))
+	if err == nil {
+		t.Fatal("expected Run() to report the CrossValidate/When conflict instead of looping forever")
+	}
+}
@@ -0,0 +1,108 @@
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/AlecAivazis/survey/v2/terminal"
+	"gopkg.in/yaml.v3"
+)
+
+// NonInteractiveResolver is implemented by Prompt types that need to
+// interpret a recorded answer before it can stand in for user input.
+// Select, for instance, only accepts a value that's actually one of its
+// Options (or a valid index into them). Prompts without special
+// requirements don't need to implement it — the recorded value is used as
+// the answer as-is.
+type NonInteractiveResolver interface {
+	resolveNonInteractive(value interface{}) (interface{}, error)
+}
+
+// WithAnswerFile loads a JSON or YAML map of prerecorded answers from path
+// (format is sniffed from its extension, defaulting to JSON) and implies
+// WithNonInteractive: every question is resolved by looking up its name in
+// that map instead of prompting, which is what CI pipelines scripting a
+// survey need.
+func WithAnswerFile(path string) AskOpt {
+	return func(options *AskOptions) error {
+		decode := json.Unmarshal
+		if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+			decode = yaml.Unmarshal
+		}
+
+		source, err := newFileAnswerSource(path, decode)
+		if err != nil {
+			return err
+		}
+
+		options.NonInteractiveSource = source
+		options.NonInteractive = true
+		return nil
+	}
+}
+
+// WithNonInteractive forces Ask to resolve every question from whatever
+// AnswerFile was configured, or failing that from the AnswerSource (see
+// readNonInteractiveAnswer), rather than prompting, regardless of the
+// options.Stdio.In TTY check; it fails with "missing answer for <name>" if
+// one isn't present.
+func WithNonInteractive() AskOpt {
+	return func(options *AskOptions) error {
+		options.NonInteractive = true
+		return nil
+	}
+}
+
+// stdinIsTerminal reports whether stdio's input looks like an interactive
+// terminal. Ask treats a non-terminal stdin the same as an explicit
+// WithNonInteractive, since there's nobody there to answer a prompt.
+func stdinIsTerminal(stdio terminal.Stdio) bool {
+	f, ok := stdio.In.(*os.File)
+	if !ok {
+		return true
+	}
+	return terminal.IsTerminal(int(f.Fd()))
+}
+
+// asIndex reports whether value names an integer index, accepting a Go
+// int (as Ask would produce), a float64 (as a JSON number decodes to), or
+// a numeric string (as a YAML scalar or dotenv value would be).
+func asIndex(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// missingAnswerError is returned when non-interactive mode can't find a
+// recorded answer for a required question.
+func missingAnswerError(name string) error {
+	return fmt.Errorf("missing answer for %s", name)
+}
+
+// readNonInteractiveAnswer looks up q's recorded answer from whichever
+// source non-interactive mode is configured with: NonInteractiveSource
+// (set by WithAnswerFile) takes priority, falling back to a general
+// AnswerSource so WithNonInteractive still works for callers using that
+// instead of a dedicated answer file.
+func readNonInteractiveAnswer(q *Question, options *AskOptions) (interface{}, bool, error) {
+	if options.NonInteractiveSource != nil {
+		return options.NonInteractiveSource.Read(q.Name)
+	}
+	if options.AnswerSource != nil {
+		return options.AnswerSource.Read(q.Name)
+	}
+	return nil, false, nil
+}
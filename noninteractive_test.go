@@ -0,0 +1,75 @@
+package survey
+
+import "testing"
+
+func TestAsIndex(t *testing.T) {
+	tests := []struct {
+		value     interface{}
+		wantIndex int
+		wantOK    bool
+	}{
+		{3, 3, true},
+		{float64(2), 2, true},
+		{"4", 4, true},
+		{"not a number", 0, false},
+		{true, 0, false},
+	}
+
+	for _, test := range tests {
+		idx, ok := asIndex(test.value)
+		if idx != test.wantIndex || ok != test.wantOK {
+			t.Errorf("asIndex(%#v) = %d, %v, want %d, %v", test.value, idx, ok, test.wantIndex, test.wantOK)
+		}
+	}
+}
+
+func TestMissingAnswerError(t *testing.T) {
+	err := missingAnswerError("favoriteColor")
+	if err == nil {
+		t.Fatal("expected a non-nil error")
+	}
+	if got, want := err.Error(), "missing answer for favoriteColor"; got != want {
+		t.Errorf("missingAnswerError(%q).Error() = %q, want %q", "favoriteColor", got, want)
+	}
+}
+
+func TestReadNonInteractiveAnswerPrefersAnswerFile(t *testing.T) {
+	options := &AskOptions{
+		NonInteractiveSource: mapAnswerSource{"name": "from-file"},
+		AnswerSource:         mapAnswerSource{"name": "from-source"},
+	}
+
+	value, ok, err := readNonInteractiveAnswer(&Question{Name: "name"}, options)
+	if err != nil {
+		t.Fatalf("readNonInteractiveAnswer() returned error: %v", err)
+	}
+	if !ok || value != "from-file" {
+		t.Errorf("readNonInteractiveAnswer() = %v, %v, want %q, true", value, ok, "from-file")
+	}
+}
+
+func TestReadNonInteractiveAnswerFallsBackToAnswerSource(t *testing.T) {
+	options := &AskOptions{
+		AnswerSource: mapAnswerSource{"name": "from-source"},
+	}
+
+	value, ok, err := readNonInteractiveAnswer(&Question{Name: "name"}, options)
+	if err != nil {
+		t.Fatalf("readNonInteractiveAnswer() returned error: %v", err)
+	}
+	if !ok || value != "from-source" {
+		t.Errorf("readNonInteractiveAnswer() = %v, %v, want %q, true", value, ok, "from-source")
+	}
+}
+
+func TestReadNonInteractiveAnswerMissing(t *testing.T) {
+	options := &AskOptions{}
+
+	_, ok, err := readNonInteractiveAnswer(&Question{Name: "name"}, options)
+	if err != nil {
+		t.Fatalf("readNonInteractiveAnswer() returned error: %v", err)
+	}
+	if ok {
+		t.Error("expected ok to be false when no source is configured")
+	}
+}
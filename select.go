@@ -2,11 +2,22 @@ package survey
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/AlecAivazis/survey/v2/terminal"
 )
 
+// optionsDebounce is how long Select waits after the last filter-changing
+// keystroke before calling OptionsFunc, so a fast typist doesn't trigger a
+// call per character.
+const optionsDebounce = 150 * time.Millisecond
+
+// spinnerFrames cycle while an OptionsFunc call is in flight.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
 /*
 Select is a prompt that presents a list of various options to the user
 for them to select using the arrow keys and enter. Response type is a string.
@@ -22,26 +33,71 @@ type Select struct {
 	core.Renderer
 	Message       string
 	Options       []string
+	Groups        []OptionGroup
 	Default       string
 	Help          string
 	PageSize      int
 	VimMode       bool
 	FilterMessage string
-	Filter        func(string, []string) []string
+	Filter        FilterFunc
+	// OptionsFunc, if set, loads Options on demand instead of using a
+	// fixed Options list: it's called with the current filter (debounced,
+	// so fast typing doesn't call it once per keystroke) whenever that
+	// filter changes, and its result replaces Options once it lands.
+	// OptionsFunc and Groups are mutually exclusive; if both are set,
+	// Groups is ignored.
+	OptionsFunc   func(filter string) ([]string, error)
 	filter        string
 	selectedIndex int
 	useDefault    bool
 	showingHelp   bool
+	loading       bool
+	loadErr       error
+	loadGen       int
+	loadResults   chan optionsLoad
+	spinnerFrame  int
+	done          chan struct{}
+}
+
+// OptionGroup is a labeled set of options that Select and MultiSelect can
+// present under a non-selectable header, for a long option list that's
+// naturally organized into categories (repos grouped by org, labels
+// grouped by kind, ...). Set Groups instead of Options to use it; Options
+// is then derived by flattening Groups in order.
+type OptionGroup struct {
+	Label   string
+	Options []string
+}
+
+// HighlightSegment is a run of characters from an option's display value
+// that either did, or didn't, contribute to the current filter match, so
+// the default templates can render matched characters differently.
+type HighlightSegment struct {
+	Text    string
+	Matched bool
+}
+
+// FilteredOption is a single entry rendered on the page: either a
+// selectable option, whose display value is broken into segments so the
+// matched characters can be highlighted, or a non-selectable Header
+// labeling the group of options that follow it.
+type FilteredOption struct {
+	Value    string
+	Segments []HighlightSegment
+	Header   bool
 }
 
 // the data available to the templates when processing
 type SelectTemplateData struct {
 	Select
-	PageEntries   []string
+	PageEntries   []FilteredOption
 	SelectedIndex int
 	Answer        string
 	ShowAnswer    bool
 	ShowHelp      bool
+	Loading       bool
+	Spinner       string
+	LoadError     string
 }
 
 var SelectQuestionTemplate = `
@@ -52,13 +108,55 @@ var SelectQuestionTemplate = `
 {{- else}}
   {{- "  "}}{{- color "cyan"}}[Use arrows to move, type to filter{{- if and .Help (not .ShowHelp)}}, {{ HelpInputRune }} for more help{{end}}]{{color "reset"}}
   {{- "\n"}}
+  {{- if .LoadError }}{{color "red"}}  {{ .LoadError }}{{color "reset"}}{{"\n"}}{{end}}
+  {{- if .Loading }}{{color "cyan"}}  {{ .Spinner }} loading options...{{color "reset"}}{{"\n"}}{{end}}
   {{- range $ix, $choice := .PageEntries}}
-    {{- if eq $ix $.SelectedIndex}}{{color "cyan+b"}}{{ SelectFocusIcon }} {{else}}{{color "default+hb"}}  {{end}}
-    {{- $choice}}
-    {{- color "reset"}}{{"\n"}}
+    {{- if $choice.Header}}
+      {{- color "default+hb"}}{{$choice.Value}}{{color "reset"}}{{"\n"}}
+    {{- else}}
+      {{- if eq $ix $.SelectedIndex}}{{color "cyan+b"}}{{ SelectFocusIcon }} {{else}}{{color "default+hb"}}  {{end}}
+      {{- range $choice.Segments}}
+        {{- if .Matched}}{{color "cyan+b"}}{{.Text}}{{color "reset"}}{{else}}{{.Text}}{{end}}
+      {{- end}}
+      {{- color "reset"}}{{"\n"}}
+    {{- end}}
   {{- end}}
 {{- end}}`
 
+// highlightOption splits value into segments according to matched, the
+// indices (into value's runes) that a FilterFunc reported as contributing
+// to the match.
+func highlightOption(value string, matched []int) FilteredOption {
+	if len(matched) == 0 {
+		return FilteredOption{Value: value, Segments: []HighlightSegment{{Text: value}}}
+	}
+
+	isMatch := make(map[int]bool, len(matched))
+	for _, idx := range matched {
+		isMatch[idx] = true
+	}
+
+	opt := FilteredOption{Value: value}
+	runes := []rune(value)
+	var current []rune
+	currentMatched := isMatch[0]
+	flush := func() {
+		if len(current) > 0 {
+			opt.Segments = append(opt.Segments, HighlightSegment{Text: string(current), Matched: currentMatched})
+			current = nil
+		}
+	}
+	for i, r := range runes {
+		if isMatch[i] != currentMatched {
+			flush()
+			currentMatched = isMatch[i]
+		}
+		current = append(current, r)
+	}
+	flush()
+	return opt
+}
+
 // OnChange is called on every keypress.
 func (s *Select) OnChange(key rune, config *PromptConfig) bool {
 	options := s.filterOptions()
@@ -131,54 +229,280 @@ func (s *Select) OnChange(key rune, config *PromptConfig) bool {
 	}
 	if oldFilter != s.filter {
 		// filter changed
+		if s.OptionsFunc != nil {
+			// kick off a debounced reload; until it lands, keep filtering
+			// whatever options we already have so typing stays responsive
+			s.scheduleLoad()
+		}
+
 		options = s.filterOptions()
 		if len(options) > 0 && len(options) <= s.selectedIndex {
 			s.selectedIndex = len(options) - 1
 		}
 	}
 
-	// figure out the options and index to render
-	// figure out the page size
+	// keep prompting, after a fresh render
+	// TODO if we have started filtering and were looking at the end of a
+	// list and we have modified the filter then we should move the page
+	// back!
+	s.render(config)
+	return false
+}
+
+// render pages the current (possibly still loading) options and draws the
+// question. It's shared by OnChange, the initial prompt, and the async
+// load loop below, since all three need the exact same page computed.
+func (s *Select) render(config *PromptConfig) error {
 	pageSize := s.PageSize
-	// if we dont have a specific one
 	if pageSize == 0 {
-		// grab the global value
 		pageSize = config.PageSize
 	}
 
-	// TODO if we have started filtering and were looking at the end of a list
-	// and we have modified the filter then we should move the page back!
-	opts, idx := paginate(pageSize, options, s.selectedIndex)
+	opts, idx := s.paginatedEntries(s.filterOptions(), pageSize, s.selectedIndex)
+
+	spinner := ""
+	if s.loading {
+		s.spinnerFrame = (s.spinnerFrame + 1) % len(spinnerFrames)
+		spinner = spinnerFrames[s.spinnerFrame]
+	}
+
+	loadError := ""
+	if s.loadErr != nil {
+		loadError = s.loadErr.Error()
+	}
 
-	// render the options
-	s.Render(
+	return s.Render(
 		SelectQuestionTemplate,
 		SelectTemplateData{
 			Select:        *s,
 			SelectedIndex: idx,
 			ShowHelp:      s.showingHelp,
 			PageEntries:   opts,
+			Loading:       s.loading,
+			Spinner:       spinner,
+			LoadError:     loadError,
 		},
 	)
+}
 
-	// keep prompting
-	return false
+// optionsLoad is the result of a debounced OptionsFunc call, tagged with
+// the generation it was requested for so a result arriving after a newer
+// keystroke started a different load can be recognized as stale and
+// discarded.
+type optionsLoad struct {
+	generation int
+	options    []string
+	err        error
 }
 
-func (s *Select) filterOptions() []string {
-	if s.filter == "" {
-		return s.Options
-	}
+// scheduleLoad arms a debounced call to OptionsFunc for the filter as it
+// currently stands. Its result is delivered on s.loadResults, tagged with
+// a generation number; a keystroke that arrives before it fires bumps
+// loadGen again via a fresh call to scheduleLoad, so whichever load was
+// already in flight finishes harmlessly into a generation nothing is
+// listening for anymore.
+func (s *Select) scheduleLoad() {
+	s.loadGen++
+	generation := s.loadGen
+	s.loading = true
+	filter := s.filter
+	results := s.loadResults
+	done := s.done
+
+	time.AfterFunc(optionsDebounce, func() {
+		options, err := s.OptionsFunc(filter)
+		// promptAsync may already have returned (the user answered before
+		// the debounce fired); don't block forever on a channel nobody is
+		// reading anymore.
+		select {
+		case results <- optionsLoad{generation: generation, options: options, err: err}:
+		case <-done:
+		}
+	})
+}
+
+// activeFilter is the FilterFunc filterOptions and groupLabelMatches rank
+// against: s.Filter if one was set, DefaultFilter otherwise.
+func (s *Select) activeFilter() FilterFunc {
 	if s.Filter != nil {
-		return s.Filter(s.filter, s.Options)
+		return s.Filter
+	}
+	return DefaultFilter
+}
+
+// flattenGroups populates s.Options from s.Groups, in order, the first
+// time something needs a flat option list to select over. Groups is the
+// structured source of truth when it's set; Options is its flat
+// projection, which is what answers are resolved against.
+func (s *Select) flattenGroups() {
+	if len(s.Groups) == 0 || len(s.Options) > 0 {
+		return
+	}
+	for _, group := range s.Groups {
+		s.Options = append(s.Options, group.Options...)
+	}
+}
+
+// groupLabelMatches reports whether label itself matches the current
+// filter, using the same FilterFunc leaf options are ranked with.
+func (s *Select) groupLabelMatches(label string) bool {
+	return len(s.activeFilter()(s.filter, []string{label})) > 0
+}
+
+// filterOptions ranks s.Options against the current filter using
+// activeFilter, returning every option unranked and in its original order
+// when there's no filter to apply. When s.Groups is set, a leaf whose
+// group label matches the filter is included too, even if the leaf text
+// itself didn't match, so filtering by group label pulls in the whole
+// group, and the results are put in the order buildItems shows them
+// (Groups order, best score first within a group) so that selectedIndex
+// names the same option here as on screen.
+func (s *Select) filterOptions() []FilterResult {
+	results := s.activeFilter()(s.filter, s.Options)
+	if len(s.Groups) == 0 || s.filter == "" {
+		return results
+	}
+
+	present := make(map[int]bool, len(results))
+	for _, r := range results {
+		present[r.Index] = true
+	}
+
+	groupOf := make(map[int]int, len(s.Options))
+	offset := 0
+	for g, group := range s.Groups {
+		for i := range group.Options {
+			groupOf[offset+i] = g
+		}
+		if s.groupLabelMatches(group.Label) {
+			for i := range group.Options {
+				if idx := offset + i; !present[idx] {
+					results = append(results, FilterResult{Index: idx})
+				}
+			}
+		}
+		offset += len(group.Options)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		ri, rj := results[i], results[j]
+		if groupOf[ri.Index] != groupOf[rj.Index] {
+			return groupOf[ri.Index] < groupOf[rj.Index]
+		}
+		if ri.Score != rj.Score {
+			return ri.Score > rj.Score
+		}
+		return ri.Index < rj.Index
+	})
+	return results
+}
+
+// buildItems interleaves results (ranked leaves, indexing into s.Options)
+// with their group headers in s.Groups order, dropping any group none of
+// whose leaves matched. Without Groups, it's just results in order.
+func (s *Select) buildItems(results []FilterResult) (items []paginateItem, matches [][]int) {
+	if len(s.Groups) == 0 {
+		items = make([]paginateItem, len(results))
+		matches = make([][]int, len(results))
+		for i, r := range results {
+			items[i] = paginateItem{Text: s.Options[r.Index], Selectable: true}
+			matches[i] = r.MatchedRunes
+		}
+		return items, matches
+	}
+
+	byIndex := make(map[int]FilterResult, len(results))
+	for _, r := range results {
+		byIndex[r.Index] = r
+	}
+
+	offset := 0
+	for _, group := range s.Groups {
+		var leafResults []FilterResult
+		leafText := make(map[int]string, len(group.Options))
+		for i, opt := range group.Options {
+			if r, ok := byIndex[offset+i]; ok {
+				leafResults = append(leafResults, r)
+				leafText[r.Index] = opt
+			}
+		}
+		offset += len(group.Options)
+
+		if len(leafResults) == 0 {
+			continue
+		}
+
+		// preserve results' ranking within the group, so a fuzzy-scored
+		// filter still sorts best matches first even when grouped
+		sort.SliceStable(leafResults, func(i, j int) bool {
+			return leafResults[i].Score > leafResults[j].Score
+		})
+
+		leaves := make([]paginateItem, len(leafResults))
+		leafMatches := make([][]int, len(leafResults))
+		for i, r := range leafResults {
+			leaves[i] = paginateItem{Text: leafText[r.Index], Selectable: true}
+			leafMatches[i] = r.MatchedRunes
+		}
+
+		items = append(items, paginateItem{Text: group.Label, Selectable: false})
+		matches = append(matches, nil)
+		items = append(items, leaves...)
+		matches = append(matches, leafMatches...)
+	}
+	return items, matches
+}
+
+// paginatedEntries pages down results the way paginate does, then converts
+// the page to FilteredOptions so matched characters can be highlighted and
+// group headers rendered distinctly.
+func (s *Select) paginatedEntries(results []FilterResult, pageSize, sel int) ([]FilteredOption, int) {
+	items, matches := s.buildItems(results)
+
+	page, idx, start := paginate(pageSize, items, sel)
+
+	opts := make([]FilteredOption, len(page))
+	for i, item := range page {
+		if !item.Selectable {
+			opts[i] = FilteredOption{Value: item.Text, Header: true}
+			continue
+		}
+		opts[i] = highlightOption(item.Text, matches[start+i])
 	}
-	return DefaultFilter(s.filter, s.Options)
+	return opts, idx
 }
 
 func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
+	// Groups and OptionsFunc are mutually exclusive: buildItems derives a
+	// group's leaves from Groups' own index bookkeeping, which an
+	// OptionsFunc reload (a differently sized/ordered Options slice) would
+	// invalidate. OptionsFunc wins when both are set.
+	if s.OptionsFunc != nil {
+		s.Groups = nil
+	}
+
+	// Groups, if set, is the source of truth; flatten it into Options
+	s.flattenGroups()
+
+	// OptionsFunc, if set, is the source of truth for Options instead;
+	// load it once synchronously for the initial (unfiltered) render,
+	// then keep it fresh as the filter changes from within the prompt
+	// loop below
+	if s.OptionsFunc != nil {
+		options, err := s.OptionsFunc(s.filter)
+		s.loadErr = err
+		s.Options = options
+	}
+
 	// if there are no options to render
 	if len(s.Options) == 0 {
-		// we failed
+		// an OptionsFunc failure is the actual reason there's nothing to
+		// select from; render it above the prompt instead of burying it
+		// behind a generic message, then report it as the Prompt error too
+		if s.loadErr != nil {
+			s.render(config)
+			return "", fmt.Errorf("could not load options: %w", s.loadErr)
+		}
 		return "", errors.New("please provide options to select from")
 	}
 
@@ -200,27 +524,8 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 	// save the selected index
 	s.selectedIndex = sel
 
-	// figure out the page size
-	pageSize := s.PageSize
-	// if we dont have a specific one
-	if pageSize == 0 {
-		// grab the global value
-		pageSize = config.PageSize
-	}
-
-	// figure out the options and index to render
-	opts, idx := paginate(pageSize, s.Options, sel)
-
 	// ask the question
-	err := s.Render(
-		SelectQuestionTemplate,
-		SelectTemplateData{
-			Select:        *s,
-			PageEntries:   opts,
-			SelectedIndex: idx,
-		},
-	)
-	if err != nil {
+	if err := s.render(config); err != nil {
 		return "", err
 	}
 
@@ -235,20 +540,26 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 	cursor.Hide()       // hide the cursor
 	defer cursor.Show() // show the cursor when we're done
 
-	// start waiting for input
-	for {
-		r, _, err := rr.ReadRune()
-		if err != nil {
-			return "", err
-		}
-		if r == terminal.KeyInterrupt {
-			return "", terminal.InterruptErr
-		}
-		if r == terminal.KeyEndTransmission {
-			break
+	if s.OptionsFunc == nil {
+		// start waiting for input
+		for {
+			r, _, err := rr.ReadRune()
+			if err != nil {
+				return "", err
+			}
+			if r == terminal.KeyInterrupt {
+				return "", terminal.InterruptErr
+			}
+			if r == terminal.KeyEndTransmission {
+				break
+			}
+			if s.OnChange(r, config) {
+				break
+			}
 		}
-		if s.OnChange(r, config) {
-			break
+	} else {
+		if err := s.promptAsync(rr, config); err != nil {
+			return "", err
 		}
 	}
 	options := s.filterOptions()
@@ -264,14 +575,125 @@ func (s *Select) Prompt(config *PromptConfig) (interface{}, error) {
 			val = s.Default
 		} else if len(options) > 0 {
 			// there is no default value so use the first
-			val = options[0]
+			val = s.Options[options[0].Index]
 		}
 		// otherwise the selected index points to the value
 	} else if s.selectedIndex < len(options) {
 		// the
-		val = options[s.selectedIndex]
+		val = s.Options[options[s.selectedIndex].Index]
+	}
+	return val, nil
+}
+
+// promptAsync runs the read loop for a Select whose OptionsFunc is set: a
+// keystroke reader goroutine is raced against s.loadResults, so a debounced
+// OptionsFunc call landing mid-typing doesn't block the next keypress, and
+// the cursor/filter stay responsive while options are (re)loaded in the
+// background. s.done is closed when this returns, so the reader goroutine
+// and any in-flight scheduleLoad call stop trying to deliver to a channel
+// nobody is reading anymore instead of blocking forever.
+func (s *Select) promptAsync(rr *terminal.RuneReader, config *PromptConfig) error {
+	s.loadResults = make(chan optionsLoad, 1)
+	s.done = make(chan struct{})
+	defer close(s.done)
+
+	done := s.done
+	keys := make(chan rune)
+	keyErrs := make(chan error, 1)
+	go func() {
+		for {
+			r, _, err := rr.ReadRune()
+			if err != nil {
+				select {
+				case keyErrs <- err:
+				case <-done:
+				}
+				return
+			}
+			select {
+			case keys <- r:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case err := <-keyErrs:
+			return err
+
+		case r := <-keys:
+			if r == terminal.KeyInterrupt {
+				return terminal.InterruptErr
+			}
+			if r == terminal.KeyEndTransmission {
+				return nil
+			}
+			if s.OnChange(r, config) {
+				return nil
+			}
+
+		case res := <-s.loadResults:
+			// a keystroke since this load started means a newer one is
+			// already in flight; let this one land in the void
+			if res.generation != s.loadGen {
+				continue
+			}
+
+			s.loading = false
+			s.loadErr = res.err
+			if res.err == nil {
+				s.Options = res.options
+			}
+			if len(s.Options) > 0 && s.selectedIndex >= len(s.Options) {
+				s.selectedIndex = len(s.Options) - 1
+			}
+
+			if err := s.render(config); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resolveNonInteractive implements NonInteractiveResolver: a recorded
+// answer for a Select must be one of Options, or a valid index into it.
+// Options is normally only populated as a side effect of Prompt, which
+// non-interactive mode never calls, so this loads it the same way Prompt
+// does first: flatten Groups, or run the initial synchronous OptionsFunc
+// call.
+func (s *Select) resolveNonInteractive(value interface{}) (interface{}, error) {
+	if s.OptionsFunc != nil {
+		s.Groups = nil
+	}
+
+	s.flattenGroups()
+
+	if s.OptionsFunc != nil {
+		options, err := s.OptionsFunc(s.filter)
+		if err != nil {
+			return nil, fmt.Errorf("could not load options: %w", err)
+		}
+		s.Options = options
+	}
+
+	if str, ok := value.(string); ok {
+		for _, opt := range s.Options {
+			if opt == str {
+				return str, nil
+			}
+		}
 	}
-	return val, err
+
+	if idx, ok := asIndex(value); ok {
+		if idx < 0 || idx >= len(s.Options) {
+			return nil, fmt.Errorf("index %d is out of range for %d options", idx, len(s.Options))
+		}
+		return s.Options[idx], nil
+	}
+
+	return nil, fmt.Errorf("%v is not one of the options for %q", value, s.Message)
 }
 
 func (s *Select) Cleanup(val interface{}) error {
@@ -0,0 +1,66 @@
+package survey
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestScheduleLoadDeliversTaggedResult(t *testing.T) {
+	s := &Select{
+		OptionsFunc: func(filter string) ([]string, error) {
+			return []string{"a", filter}, nil
+		},
+	}
+	s.filter = "x"
+	s.loadResults = make(chan optionsLoad, 1)
+	s.done = make(chan struct{})
+	defer close(s.done)
+
+	s.scheduleLoad()
+
+	select {
+	case res := <-s.loadResults:
+		if res.generation != s.loadGen {
+			t.Errorf("expected the result tagged with the generation it was scheduled at, got %d want %d", res.generation, s.loadGen)
+		}
+		if len(res.options) != 2 || res.options[1] != "x" {
+			t.Errorf("expected OptionsFunc's result to reach loadResults unchanged, got %+v", res.options)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for scheduleLoad's debounced result")
+	}
+}
+
+func TestScheduleLoadDoesNotBlockOnceDone(t *testing.T) {
+	s := &Select{
+		OptionsFunc: func(filter string) ([]string, error) {
+			return []string{"a"}, nil
+		},
+	}
+	// unbuffered and never drained, so the old (pre-fix) blocking send
+	// would hang the OptionsFunc goroutine forever once done is closed
+	s.loadResults = make(chan optionsLoad)
+	s.done = make(chan struct{})
+
+	runtime.GC()
+	before := runtime.NumGoroutine()
+
+	s.scheduleLoad()
+	close(s.done)
+
+	// give the debounced goroutine time to fire and, if the fix is working,
+	// exit via its done case instead of blocking on the unbuffered send
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		time.Sleep(optionsDebounce + 20*time.Millisecond)
+		runtime.GC()
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutine count didn't return to baseline (%d) after done was closed: still %d",
+				before, runtime.NumGoroutine())
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package survey
+
+import "testing"
+
+func TestBuildItemsWithoutGroups(t *testing.T) {
+	s := &Select{Options: []string{"a", "b", "c"}}
+
+	items, matches := s.buildItems([]FilterResult{{Index: 0}, {Index: 2}})
+	if len(items) != 2 || len(matches) != 2 {
+		t.Fatalf("expected 2 items, got %+v", items)
+	}
+	if items[0].Text != "a" || items[1].Text != "c" {
+		t.Errorf("expected items in results order, got %+v", items)
+	}
+	for _, item := range items {
+		if !item.Selectable {
+			t.Errorf("expected every item to be selectable without Groups, got %+v", item)
+		}
+	}
+}
+
+func TestBuildItemsGroupsLeavesByScore(t *testing.T) {
+	s := &Select{
+		Groups: []OptionGroup{
+			{Label: "fruit", Options: []string{"apple", "banana", "cherry"}},
+		},
+	}
+	s.flattenGroups()
+
+	// "banana" (index 1) scores highest, "cherry" (index 2) next, "apple"
+	// (index 0) last - buildItems must preserve this ranking rather than
+	// falling back to Options' declared order.
+	results := []FilterResult{
+		{Index: 0, Score: 1},
+		{Index: 1, Score: 30},
+		{Index: 2, Score: 15},
+	}
+
+	items, _ := s.buildItems(results)
+	if len(items) != 4 {
+		t.Fatalf("expected a header plus 3 leaves, got %+v", items)
+	}
+	if items[0].Selectable {
+		t.Fatalf("expected items[0] to be the non-selectable group header, got %+v", items[0])
+	}
+	got := []string{items[1].Text, items[2].Text, items[3].Text}
+	want := []string{"banana", "cherry", "apple"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected leaves ordered by score %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestBuildItemsDropsGroupsWithNoMatches(t *testing.T) {
+	s := &Select{
+		Groups: []OptionGroup{
+			{Label: "matches", Options: []string{"a"}},
+			{Label: "empty", Options: []string{"b"}},
+		},
+	}
+	s.flattenGroups()
+
+	items, _ := s.buildItems([]FilterResult{{Index: 0}})
+	for _, item := range items {
+		if item.Text == "empty" {
+			t.Errorf("expected a group with no matching leaves to be dropped entirely, got %+v", items)
+		}
+	}
+}
+
+func TestResolveNonInteractivePopulatesOptionsFromGroups(t *testing.T) {
+	s := &Select{
+		Groups: []OptionGroup{
+			{Label: "fruit", Options: []string{"apple", "banana"}},
+		},
+	}
+
+	got, err := s.resolveNonInteractive("banana")
+	if err != nil {
+		t.Fatalf("resolveNonInteractive() returned error: %v", err)
+	}
+	if got != "banana" {
+		t.Errorf("resolveNonInteractive() = %v, want %q", got, "banana")
+	}
+}
+
+func TestResolveNonInteractivePopulatesOptionsFromOptionsFunc(t *testing.T) {
+	s := &Select{
+		OptionsFunc: func(filter string) ([]string, error) {
+			return []string{"x", "y", "z"}, nil
+		},
+	}
+
+	got, err := s.resolveNonInteractive(1)
+	if err != nil {
+		t.Fatalf("resolveNonInteractive() returned error: %v", err)
+	}
+	if got != "y" {
+		t.Errorf("resolveNonInteractive() = %v, want %q", got, "y")
+	}
+}
+
+func TestSelectablePosition(t *testing.T) {
+	items := []paginateItem{
+		{Text: "header", Selectable: false},
+		{Text: "a", Selectable: true},
+		{Text: "b", Selectable: true},
+	}
+
+	if pos := selectablePosition(items, 0); pos != 1 {
+		t.Errorf("selectablePosition(items, 0) = %d, want 1", pos)
+	}
+	if pos := selectablePosition(items, 1); pos != 2 {
+		t.Errorf("selectablePosition(items, 1) = %d, want 2", pos)
+	}
+}
+
+func TestPaginateSkipsNonSelectableCursor(t *testing.T) {
+	items := []paginateItem{
+		{Text: "header", Selectable: false},
+		{Text: "a", Selectable: true},
+		{Text: "b", Selectable: true},
+	}
+
+	page, cursor, start := paginate(10, items, 1)
+	if start != 0 || len(page) != 3 {
+		t.Fatalf("expected the whole page back, got start=%d page=%+v", start, page)
+	}
+	if page[cursor].Text != "b" {
+		t.Errorf("expected the cursor to land on the 2nd selectable item 'b', got %q", page[cursor].Text)
+	}
+}
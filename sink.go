@@ -0,0 +1,240 @@
+package survey
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// AnswerSink receives answers as Ask collects them, as an alternative to
+// writing them into a struct or map via core.WriteAnswer. Write is called
+// once per question, in order; Flush is called once Ask has finished with
+// every question, so file-backed sinks can persist everything at once.
+type AnswerSink interface {
+	Write(name string, value interface{}) error
+	Flush() error
+}
+
+// AnswerSource supplies previously recorded answers, the symmetric
+// counterpart to AnswerSink. When one is configured via WithAnswerSource,
+// Ask calls Read for every question before prompting; a true ok short-
+// circuits the prompt and uses value instead, still running Validate and
+// Transform on it.
+type AnswerSource interface {
+	Read(name string) (value interface{}, ok bool, err error)
+}
+
+// WithAnswerSink directs Ask to additionally write every answer to sink as
+// it's collected, and to Flush it once the questions are exhausted. When
+// Flush is set to flush to a file, this is how callers such as `tea`
+// persist a wizard's results directly without an intermediate struct.
+func WithAnswerSink(sink AnswerSink) AskOpt {
+	return func(options *AskOptions) error {
+		options.AnswerSink = sink
+		return nil
+	}
+}
+
+// WithAnswerSource directs Ask to resolve an answer for each question from
+// source before falling back to the interactive prompt. It's the
+// replay-side counterpart of WithAnswerSink: recording a run's answers to
+// one of the file sinks below and feeding the same file back in through
+// the matching AnswerSource reproduces the run without a terminal.
+func WithAnswerSource(source AnswerSource) AskOpt {
+	return func(options *AskOptions) error {
+		options.AnswerSource = source
+		return nil
+	}
+}
+
+// FuncAnswerSink adapts a plain function to the AnswerSink interface, for
+// callers that want to react to answers as they come in (logging them,
+// forwarding them over a channel, ...) rather than accumulate them into a
+// file. Flush is a no-op.
+type FuncAnswerSink func(name string, value interface{}) error
+
+func (f FuncAnswerSink) Write(name string, value interface{}) error { return f(name, value) }
+func (f FuncAnswerSink) Flush() error                               { return nil }
+
+// fileAnswerSink accumulates answers into a map and serializes the whole
+// map to path on Flush, using encode to render it. It backs every built-in
+// file format sink; only the encoder differs between them.
+type fileAnswerSink struct {
+	path    string
+	encode  func(map[string]interface{}) ([]byte, error)
+	answers map[string]interface{}
+}
+
+func newFileAnswerSink(path string, encode func(map[string]interface{}) ([]byte, error)) AnswerSink {
+	return &fileAnswerSink{path: path, encode: encode, answers: map[string]interface{}{}}
+}
+
+func (s *fileAnswerSink) Write(name string, value interface{}) error {
+	s.answers[name] = value
+	return nil
+}
+
+func (s *fileAnswerSink) Flush() error {
+	out, err := s.encode(s.answers)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, out, 0o644)
+}
+
+// NewJSONAnswerSink writes collected answers to path as a single JSON
+// object once Ask finishes.
+func NewJSONAnswerSink(path string) AnswerSink {
+	return newFileAnswerSink(path, func(answers map[string]interface{}) ([]byte, error) {
+		return json.MarshalIndent(answers, "", "  ")
+	})
+}
+
+// NewYAMLAnswerSink writes collected answers to path as a YAML document
+// once Ask finishes.
+func NewYAMLAnswerSink(path string) AnswerSink {
+	return newFileAnswerSink(path, func(answers map[string]interface{}) ([]byte, error) {
+		return yaml.Marshal(answers)
+	})
+}
+
+// NewTOMLAnswerSink writes collected answers to path as a TOML document
+// once Ask finishes.
+func NewTOMLAnswerSink(path string) AnswerSink {
+	return newFileAnswerSink(path, func(answers map[string]interface{}) ([]byte, error) {
+		var buf strings.Builder
+		if err := toml.NewEncoder(&buf).Encode(answers); err != nil {
+			return nil, err
+		}
+		return []byte(buf.String()), nil
+	})
+}
+
+// NewDotenvAnswerSink writes collected answers to path as KEY=value lines
+// once Ask finishes. Question names are upper-cased to look like the
+// environment variables they're meant to seed; NewDotenvAnswerSource
+// canonicalizes the same way on read, so any single name round-trips
+// regardless of its original case. Two question names that differ only by
+// case collide into the same KEY, exactly as they would as real
+// environment variables.
+func NewDotenvAnswerSink(path string) AnswerSink {
+	return newFileAnswerSink(path, func(answers map[string]interface{}) ([]byte, error) {
+		names := make([]string, 0, len(answers))
+		for name := range answers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var buf strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&buf, "%s=%s\n", strings.ToUpper(name), dotenvQuote(answers[name]))
+		}
+		return []byte(buf.String()), nil
+	})
+}
+
+// dotenvQuote renders value the way a dotenv file expects: quoted whenever
+// it isn't a single bare word, so spaces and empty strings round-trip.
+func dotenvQuote(value interface{}) string {
+	str := fmt.Sprintf("%v", value)
+	if str != "" && !strings.ContainsAny(str, " \t\"'\n") {
+		return str
+	}
+	return strconv.Quote(str)
+}
+
+// fileAnswerSource loads a whole file of recorded answers eagerly, then
+// answers Read from the in-memory map. It backs every built-in file format
+// source; only the decoder differs between them.
+type fileAnswerSource struct {
+	answers map[string]interface{}
+}
+
+func newFileAnswerSource(path string, decode func([]byte, interface{}) error) (AnswerSource, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := map[string]interface{}{}
+	if err := decode(raw, &answers); err != nil {
+		return nil, fmt.Errorf("could not parse recorded answers in %s: %w", path, err)
+	}
+	return &fileAnswerSource{answers: answers}, nil
+}
+
+func (s *fileAnswerSource) Read(name string) (interface{}, bool, error) {
+	value, ok := s.answers[name]
+	return value, ok, nil
+}
+
+// NewJSONAnswerSource loads recorded answers from the JSON object in path.
+func NewJSONAnswerSource(path string) (AnswerSource, error) {
+	return newFileAnswerSource(path, json.Unmarshal)
+}
+
+// NewYAMLAnswerSource loads recorded answers from the YAML document in
+// path.
+func NewYAMLAnswerSource(path string) (AnswerSource, error) {
+	return newFileAnswerSource(path, yaml.Unmarshal)
+}
+
+// NewTOMLAnswerSource loads recorded answers from the TOML document in
+// path.
+func NewTOMLAnswerSource(path string) (AnswerSource, error) {
+	return newFileAnswerSource(path, func(raw []byte, out interface{}) error {
+		return toml.Unmarshal(raw, out)
+	})
+}
+
+// NewDotenvAnswerSource loads recorded answers from the KEY=value lines in
+// path. Read looks a question name up by its upper-cased form, matching how
+// NewDotenvAnswerSink wrote it, so a name round-trips whatever case it was
+// originally written in; two names that differ only by case are, as with
+// real environment variables, indistinguishable and will collide.
+func NewDotenvAnswerSource(path string) (AnswerSource, error) {
+	source, err := newFileAnswerSource(path, func(raw []byte, out interface{}) error {
+		answers := out.(*map[string]interface{})
+		scanner := bufio.NewScanner(strings.NewReader(string(raw)))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			name, value, found := strings.Cut(line, "=")
+			if !found {
+				continue
+			}
+
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				unquoted = value
+			}
+			(*answers)[strings.ToUpper(strings.TrimSpace(name))] = unquoted
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return caseInsensitiveAnswerSource{source}, nil
+}
+
+// caseInsensitiveAnswerSource upper-cases a name before delegating Read, so
+// a source keyed by upper-cased names (as NewDotenvAnswerSource's decoder
+// stores them) can still be looked up by the question's original-case name.
+type caseInsensitiveAnswerSource struct {
+	AnswerSource
+}
+
+func (s caseInsensitiveAnswerSource) Read(name string) (interface{}, bool, error) {
+	return s.AnswerSource.Read(strings.ToUpper(name))
+}
@@ -0,0 +1,116 @@
+package survey
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDotenvQuote(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{"bare", "bare"},
+		{"", `""`},
+		{"has space", `"has space"`},
+		{42, "42"},
+	}
+
+	for _, test := range tests {
+		if got := dotenvQuote(test.value); got != test.expected {
+			t.Errorf("dotenvQuote(%#v) = %q, want %q", test.value, got, test.expected)
+		}
+	}
+}
+
+func TestJSONAnswerSinkAndSourceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	sink := NewJSONAnswerSink(path)
+	if err := sink.Write("name", "Larry Bird"); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	source, err := NewJSONAnswerSource(path)
+	if err != nil {
+		t.Fatalf("NewJSONAnswerSource() returned error: %v", err)
+	}
+
+	value, ok, err := source.Read("name")
+	if err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if !ok || value != "Larry Bird" {
+		t.Errorf("Read(\"name\") = %v, %v, want %q, true", value, ok, "Larry Bird")
+	}
+
+	if _, ok, _ := source.Read("missing"); ok {
+		t.Errorf("Read(\"missing\") reported ok for a name that was never written")
+	}
+}
+
+func TestDotenvAnswerSinkAndSourceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.env")
+
+	sink := NewDotenvAnswerSink(path)
+	// a mixed-case name must round-trip too: the source canonicalizes to
+	// upper-case on read the same way the sink does on write
+	if err := sink.Write("name", "Larry Bird"); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if err := sink.Write("favoriteColor", "red"); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	source, err := NewDotenvAnswerSource(path)
+	if err != nil {
+		t.Fatalf("NewDotenvAnswerSource() returned error: %v", err)
+	}
+
+	value, ok, err := source.Read("name")
+	if err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if !ok || value != "Larry Bird" {
+		t.Errorf("Read(\"name\") = %v, %v, want %q, true", value, ok, "Larry Bird")
+	}
+
+	value, ok, err = source.Read("favoriteColor")
+	if err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if !ok || value != "red" {
+		t.Errorf("Read(\"favoriteColor\") = %v, %v, want %q, true", value, ok, "red")
+	}
+}
+
+func TestYAMLAnswerSinkAndSourceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+
+	sink := NewYAMLAnswerSink(path)
+	if err := sink.Write("name", "Larry Bird"); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	if err := sink.Flush(); err != nil {
+		t.Fatalf("Flush() returned error: %v", err)
+	}
+
+	source, err := NewYAMLAnswerSource(path)
+	if err != nil {
+		t.Fatalf("NewYAMLAnswerSource() returned error: %v", err)
+	}
+
+	value, ok, err := source.Read("name")
+	if err != nil {
+		t.Fatalf("Read() returned error: %v", err)
+	}
+	if !ok || value != "Larry Bird" {
+		t.Errorf("Read(\"name\") = %v, %v, want %q, true", value, ok, "Larry Bird")
+	}
+}
@@ -2,6 +2,7 @@ package survey
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 
@@ -64,9 +65,13 @@ type AskOpt func(options *AskOptions) error
 
 // AskOptions provides additional options on ask.
 type AskOptions struct {
-	Stdio        terminal.Stdio
-	Validators   []Validator
-	PromptConfig PromptConfig
+	Stdio                terminal.Stdio
+	Validators           []Validator
+	PromptConfig         PromptConfig
+	AnswerSink           AnswerSink
+	AnswerSource         AnswerSource
+	NonInteractive       bool
+	NonInteractiveSource AnswerSource
 }
 
 // WithStdio specifies the standard input, output and error files survey
@@ -160,12 +165,17 @@ func Ask(qs []*Question, response interface{}, opts ...AskOpt) error {
 		}
 	}
 
-	// if we weren't passed a place to record the answers
-	if response == nil {
+	// if we weren't passed a place to record the answers, and nothing else
+	// is going to receive them either
+	if response == nil && options.AnswerSink == nil {
 		// we can't go any further
 		return errors.New("cannot call Ask() with a nil reference to record the answers")
 	}
 
+	// a non-tty stdin means there's nobody to answer a prompt, same as an
+	// explicit WithNonInteractive
+	nonInteractive := options.NonInteractive || !stdinIsTerminal(options.Stdio)
+
 	// go over every question
 	for _, q := range qs {
 		// If Prompt implements controllable stdio, pass in specified stdio.
@@ -173,11 +183,49 @@ func Ask(qs []*Question, response interface{}, opts ...AskOpt) error {
 			p.WithStdio(options.Stdio)
 		}
 
-		// grab the user input and save it
-		ans, err := q.Prompt.Prompt(&options.PromptConfig)
-		// if there was a problem
-		if err != nil {
-			return err
+		var ans interface{}
+		var err error
+		resolved := false
+
+		if nonInteractive {
+			// in non-interactive mode every question must come from the
+			// recorded answers; there's no prompt to fall back to
+			raw, ok, srcErr := readNonInteractiveAnswer(q, &options)
+			if srcErr != nil {
+				return srcErr
+			}
+			if !ok {
+				return missingAnswerError(q.Name)
+			}
+			if resolver, ok := q.Prompt.(NonInteractiveResolver); ok {
+				ans, err = resolver.resolveNonInteractive(raw)
+			} else {
+				ans = raw
+			}
+			if err != nil {
+				return fmt.Errorf("invalid answer for %s: %w", q.Name, err)
+			}
+			resolved = true
+		} else if options.AnswerSource != nil {
+			// an AnswerSource can also be used interactively, filling in
+			// questions it has a recorded value for and prompting the rest
+			raw, ok, srcErr := options.AnswerSource.Read(q.Name)
+			if srcErr != nil {
+				return srcErr
+			}
+			if ok {
+				ans = raw
+				resolved = true
+			}
+		}
+
+		if !resolved {
+			// grab the user input and save it
+			ans, err = q.Prompt.Prompt(&options.PromptConfig)
+			// if there was a problem
+			if err != nil {
+				return err
+			}
 		}
 
 		// build up a list of validators that we have to apply to this question
@@ -196,6 +244,12 @@ func Ask(qs []*Question, response interface{}, opts ...AskOpt) error {
 		for _, validator := range validators {
 			// wait for a valid response
 			for invalid := validator(ans); invalid != nil; invalid = validator(ans) {
+				// a non-interactive answer gets exactly one shot; there's
+				// no user to re-prompt
+				if nonInteractive {
+					return fmt.Errorf("invalid answer for %s: %w", q.Name, invalid)
+				}
+
 				err := q.Prompt.Error(invalid)
 				// if there was a problem
 				if err != nil {
@@ -233,41 +287,67 @@ func Ask(qs []*Question, response interface{}, opts ...AskOpt) error {
 			return err
 		}
 
-		// add it to the map
-		err = core.WriteAnswer(response, q.Name, ans)
-		// if something went wrong
-		if err != nil {
-			return err
+		// hand the answer to whichever sink wants it
+		if options.AnswerSink != nil {
+			if err := options.AnswerSink.Write(q.Name, ans); err != nil {
+				return err
+			}
+		}
+		if response != nil {
+			// add it to the map
+			if err := core.WriteAnswer(response, q.Name, ans); err != nil {
+				return err
+			}
 		}
+	}
 
+	if options.AnswerSink != nil {
+		if err := options.AnswerSink.Flush(); err != nil {
+			return err
+		}
 	}
 
 	// return the response
 	return nil
 }
 
-// paginate returns a single page of choices given the page size, the total list of
-// possible choices, and the current selected index in the total list.
-func paginate(pageSize int, choices []string, sel int) ([]string, int) {
+// paginateItem is a single row to page through: Text is what's shown, and
+// Selectable says whether the cursor can land on it. A flat list of
+// choices is all Selectable; Select's group headers are the one kind of
+// row that isn't.
+type paginateItem struct {
+	Text       string
+	Selectable bool
+}
+
+// paginate returns a single page of items given the page size, the total
+// list of items, and sel, the index of the current selection among the
+// *selectable* items — non-selectable rows (group headers) are paged along
+// for context but can never hold the cursor themselves. The start index of
+// the returned page within items is also returned so callers can slice a
+// second, parallel list the same way.
+func paginate(pageSize int, items []paginateItem, sel int) ([]paginateItem, int, int) {
 	var start, end, cursor int
 
-	if len(choices) < pageSize {
-		// if we dont have enough options to fill a page
+	pos := selectablePosition(items, sel)
+
+	if len(items) < pageSize {
+		// if we dont have enough items to fill a page
 		start = 0
-		end = len(choices)
-		cursor = sel
+		end = len(items)
+		cursor = pos
 
-	} else if sel < pageSize/2 {
+	} else if pos < pageSize/2 {
 		// if we are in the first half page
 		start = 0
 		end = pageSize
-		cursor = sel
+		cursor = pos
 
-	} else if len(choices)-sel-1 < pageSize/2 {
+	} else if len(items)-pos-1 < pageSize/2 {
 		// if we are in the last half page
-		start = len(choices) - pageSize
-		end = len(choices)
-		cursor = sel - start
+		start = len(items) - pageSize
+		end = len(items)
+		cursor = pos - start
 
 	} else {
 		// somewhere in the middle
@@ -275,10 +355,26 @@ func paginate(pageSize int, choices []string, sel int) ([]string, int) {
 		below := pageSize - above
 
 		cursor = pageSize / 2
-		start = sel - above
-		end = sel + below
+		start = pos - above
+		end = pos + below
 	}
 
-	// return the subset we care about and the index
-	return choices[start:end], cursor
+	// return the subset we care about, the index, and where it started
+	return items[start:end], cursor, start
+}
+
+// selectablePosition returns items' index of the sel-th selectable row
+// (0-based), defaulting to 0 if items doesn't have that many.
+func selectablePosition(items []paginateItem, sel int) int {
+	seen := -1
+	for i, item := range items {
+		if !item.Selectable {
+			continue
+		}
+		seen++
+		if seen == sel {
+			return i
+		}
+	}
+	return 0
 }